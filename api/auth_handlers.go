@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Gabdullin-Nursultan/Expense-Tracker-Api/auth"
+	"github.com/Gabdullin-Nursultan/Expense-Tracker-Api/storage"
+)
+
+// credentials is the JSON body expected by Register and Login.
+type credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// tokenResponse is the JSON body returned by Register and Login.
+type tokenResponse struct {
+	Token string `json:"token"`
+}
+
+// Register handles POST /auth/register.
+func (s *Server) Register(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil || creds.Email == "" || creds.Password == "" {
+		writeError(w, http.StatusBadRequest, "invalid_input", "email and password are required")
+		return
+	}
+
+	hash, err := auth.HashPassword(creds.Password)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", "Error creating account")
+		return
+	}
+
+	user := storage.User{Email: creds.Email, PasswordHash: hash}
+	if err := s.repo.CreateUser(r.Context(), &user); err != nil {
+		if err == storage.ErrUserExists {
+			writeError(w, http.StatusConflict, "user_exists", "An account with that email already exists")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal_error", "Error creating account")
+		return
+	}
+
+	token, err := auth.GenerateToken(s.jwtSecret, user.ID, s.tokenTTL)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", "Error creating token")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, tokenResponse{Token: token})
+}
+
+// Login handles POST /auth/login.
+func (s *Server) Login(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_input", "email and password are required")
+		return
+	}
+
+	user, err := s.repo.GetUserByEmail(r.Context(), creds.Email)
+	if err != nil || !auth.CheckPassword(user.PasswordHash, creds.Password) {
+		writeError(w, http.StatusUnauthorized, "invalid_credentials", "Invalid email or password")
+		return
+	}
+
+	token, err := auth.GenerateToken(s.jwtSecret, user.ID, s.tokenTTL)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", "Error creating token")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tokenResponse{Token: token})
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Gabdullin-Nursultan/Expense-Tracker-Api/storage"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo := storage.NewJSONFileRepository(
+		filepath.Join(dir, "expenses.json"),
+		filepath.Join(dir, "users.json"),
+		filepath.Join(dir, "budgets.json"),
+	)
+
+	return NewServer(repo, []byte("test-secret"), time.Hour)
+}
+
+func postJSON(t *testing.T, handler http.HandlerFunc, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshaling request body: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	return rec
+}
+
+// TestAuth_RegisterThenLogin is a round-trip test against the JSON-file
+// backend: it would have caught storage.User's PasswordHash being dropped
+// on save, since login only succeeds if the hash written by Register is
+// read back correctly.
+func TestAuth_RegisterThenLogin(t *testing.T) {
+	s := newTestServer(t)
+
+	registerRec := postJSON(t, s.Register, "/auth/register", credentials{Email: "alice@example.com", Password: "hunter2"})
+	if registerRec.Code != http.StatusCreated {
+		t.Fatalf("Register returned status %d, body %s", registerRec.Code, registerRec.Body)
+	}
+
+	loginRec := postJSON(t, s.Login, "/auth/login", credentials{Email: "alice@example.com", Password: "hunter2"})
+	if loginRec.Code != http.StatusOK {
+		t.Fatalf("Login returned status %d, body %s", loginRec.Code, loginRec.Body)
+	}
+
+	var resp tokenResponse
+	if err := json.Unmarshal(loginRec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding login response: %v", err)
+	}
+	if resp.Token == "" {
+		t.Fatalf("Login returned an empty token")
+	}
+}
+
+func TestAuth_LoginWithWrongPassword(t *testing.T) {
+	s := newTestServer(t)
+
+	postJSON(t, s.Register, "/auth/register", credentials{Email: "alice@example.com", Password: "hunter2"})
+
+	rec := postJSON(t, s.Login, "/auth/login", credentials{Email: "alice@example.com", Password: "wrong"})
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Login with the wrong password returned status %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestAuth_RegisterDuplicateEmail(t *testing.T) {
+	s := newTestServer(t)
+
+	postJSON(t, s.Register, "/auth/register", credentials{Email: "alice@example.com", Password: "hunter2"})
+
+	rec := postJSON(t, s.Register, "/auth/register", credentials{Email: "alice@example.com", Password: "different"})
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("Register with a duplicate email returned status %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
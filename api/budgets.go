@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Gabdullin-Nursultan/Expense-Tracker-Api/storage"
+)
+
+// budgetRequest is the JSON body accepted by CreateBudget and UpdateBudget.
+type budgetRequest struct {
+	Category       string  `json:"category"`
+	Month          string  `json:"month" validate:"required"`
+	Amount         int     `json:"amount" validate:"required,gt=0"`
+	AlertThreshold float64 `json:"alert_threshold" validate:"gte=0,lte=1"`
+}
+
+// ListBudgets handles GET /budgets.
+func (s *Server) ListBudgets(w http.ResponseWriter, r *http.Request) {
+	budgets, err := s.repo.ListBudgets(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error loading budgets")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, budgets)
+}
+
+// GetBudget handles GET /budgets/{id}.
+func (s *Server) GetBudget(w http.ResponseWriter, r *http.Request) {
+	id, err := idFromRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Invalid budget ID")
+		return
+	}
+
+	budget, err := s.repo.GetBudget(r.Context(), id)
+	if err != nil {
+		if err == storage.ErrBudgetNotFound {
+			writeError(w, http.StatusNotFound, "not_found", "Budget not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error loading budget")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, budget)
+}
+
+// CreateBudget handles POST /budgets.
+func (s *Server) CreateBudget(w http.ResponseWriter, r *http.Request) {
+	var req budgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_input", "Invalid input")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	budget := storage.Budget{
+		Category:       req.Category,
+		Month:          req.Month,
+		Amount:         req.Amount,
+		AlertThreshold: req.AlertThreshold,
+	}
+
+	if err := s.repo.CreateBudget(r.Context(), &budget); err != nil {
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error saving budget")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, budget)
+}
+
+// UpdateBudget handles PUT /budgets/{id}.
+func (s *Server) UpdateBudget(w http.ResponseWriter, r *http.Request) {
+	id, err := idFromRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Invalid budget ID")
+		return
+	}
+
+	var req budgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_input", "Invalid input")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	budget := storage.Budget{
+		ID:             id,
+		Category:       req.Category,
+		Month:          req.Month,
+		Amount:         req.Amount,
+		AlertThreshold: req.AlertThreshold,
+	}
+
+	if err := s.repo.UpdateBudget(r.Context(), &budget); err != nil {
+		if err == storage.ErrBudgetNotFound {
+			writeError(w, http.StatusNotFound, "not_found", "Budget not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error saving budget")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, budget)
+}
+
+// DeleteBudget handles DELETE /budgets/{id}.
+func (s *Server) DeleteBudget(w http.ResponseWriter, r *http.Request) {
+	id, err := idFromRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Invalid budget ID")
+		return
+	}
+
+	if err := s.repo.DeleteBudget(r.Context(), id); err != nil {
+		if err == storage.ErrBudgetNotFound {
+			writeError(w, http.StatusNotFound, "not_found", "Budget not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error saving budget")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// budgetStatus reports how much of a budget has been spent.
+type budgetStatus struct {
+	Spent       int     `json:"spent"`
+	Budget      int     `json:"budget"`
+	Remaining   int     `json:"remaining"`
+	PercentUsed float64 `json:"percent_used"`
+	Alert       bool    `json:"alert"`
+}
+
+// overallBudgetKey is the budget_status key for a Budget with no Category,
+// i.e. one that applies across every category.
+const overallBudgetKey = "overall"
+
+// currentMonthBudgetStatus compares this calendar month's spend per
+// category against the user's budgets for that month.
+func currentMonthBudgetStatus(ctx context.Context, repo storage.Repository) (map[string]budgetStatus, error) {
+	now := time.Now()
+	month := now.Format("2006-01")
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	budgets, err := repo.ListBudgets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var active []storage.Budget
+	for _, b := range budgets {
+		if b.Month == month {
+			active = append(active, b)
+		}
+	}
+	if len(active) == 0 {
+		return nil, nil
+	}
+
+	summary, err := repo.Summary(ctx, storage.Filter{From: from, To: now})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := map[string]budgetStatus{}
+	for _, b := range active {
+		key := b.Category
+		spent := summary.ByCategory[b.Category]
+		if key == "" {
+			key = overallBudgetKey
+			spent = summary.Total
+		}
+
+		statuses[key] = budgetStatusFor(b, spent)
+	}
+
+	return statuses, nil
+}
+
+func budgetStatusFor(b storage.Budget, spent int) budgetStatus {
+	var percent float64
+	if b.Amount > 0 {
+		percent = float64(spent) / float64(b.Amount)
+	}
+
+	return budgetStatus{
+		Spent:       spent,
+		Budget:      b.Amount,
+		Remaining:   b.Amount - spent,
+		PercentUsed: percent,
+		Alert:       percent >= b.AlertThreshold,
+	}
+}
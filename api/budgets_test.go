@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Gabdullin-Nursultan/Expense-Tracker-Api/storage"
+)
+
+func withTestUser(req *http.Request, userID int) *http.Request {
+	return req.WithContext(storage.WithUserID(req.Context(), userID))
+}
+
+// TestBudgetAlert_FiresWebhookOverThreshold exercises the same budget-alert
+// path a real request through auth.Middleware would: create a budget, add
+// an expense that pushes spend over its alert threshold, and confirm both
+// the x-budget-alert response header and the configured webhook fire.
+func TestBudgetAlert_FiresWebhookOverThreshold(t *testing.T) {
+	s := newTestServer(t)
+	const userID = 1
+
+	received := make(chan map[string]interface{}, 1)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		received <- payload
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer hook.Close()
+
+	ctx := storage.WithUserID(context.Background(), userID)
+	if err := s.repo.CreateUser(ctx, &storage.User{Email: "alice@example.com", PasswordHash: "x"}); err != nil {
+		t.Fatalf("seeding user: %v", err)
+	}
+	// CreateUser assigns its own ID starting at 1, matching userID above.
+	if err := s.repo.SetWebhookURL(ctx, userID, hook.URL); err != nil {
+		t.Fatalf("SetWebhookURL: %v", err)
+	}
+
+	month := time.Now().Format("2006-01")
+	budgetRec := postJSON(t, func(w http.ResponseWriter, r *http.Request) {
+		s.CreateBudget(w, withTestUser(r, userID))
+	}, "/budgets", budgetRequest{Month: month, Amount: 1000, AlertThreshold: 0.5})
+	if budgetRec.Code != http.StatusCreated {
+		t.Fatalf("CreateBudget returned status %d, body %s", budgetRec.Code, budgetRec.Body)
+	}
+
+	expenseRec := postJSON(t, func(w http.ResponseWriter, r *http.Request) {
+		s.AddExpense(w, withTestUser(r, userID))
+	}, "/expenses", expenseRequest{Description: "rent", Category: "housing", Amount: 600})
+	if expenseRec.Code != http.StatusCreated {
+		t.Fatalf("AddExpense returned status %d, body %s", expenseRec.Code, expenseRec.Body)
+	}
+	if expenseRec.Header().Get("x-budget-alert") != "true" {
+		t.Fatalf("AddExpense response missing x-budget-alert header: %v", expenseRec.Header())
+	}
+
+	select {
+	case payload := <-received:
+		if payload["user_id"] != float64(userID) {
+			t.Fatalf("webhook payload user_id = %v, want %v", payload["user_id"], userID)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("webhook was not called within 1s")
+	}
+}
+
+func TestBudgetAlert_NoAlertUnderThreshold(t *testing.T) {
+	s := newTestServer(t)
+	const userID = 1
+
+	month := time.Now().Format("2006-01")
+	postJSON(t, func(w http.ResponseWriter, r *http.Request) {
+		s.CreateBudget(w, withTestUser(r, userID))
+	}, "/budgets", budgetRequest{Month: month, Amount: 1000, AlertThreshold: 0.9})
+
+	expenseRec := postJSON(t, func(w http.ResponseWriter, r *http.Request) {
+		s.AddExpense(w, withTestUser(r, userID))
+	}, "/expenses", expenseRequest{Description: "coffee", Category: "food", Amount: 100})
+	if expenseRec.Code != http.StatusCreated {
+		t.Fatalf("AddExpense returned status %d, body %s", expenseRec.Code, expenseRec.Body)
+	}
+	if expenseRec.Header().Get("x-budget-alert") != "" {
+		t.Fatalf("AddExpense set x-budget-alert under threshold: %v", expenseRec.Header())
+	}
+}
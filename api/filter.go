@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Gabdullin-Nursultan/Expense-Tracker-Api/storage"
+)
+
+// filterFromQuery builds a storage.Filter out of the category/from/to/
+// min_amount/max_amount query parameters. Unset parameters leave the
+// corresponding Filter field at its zero value ("no restriction").
+func filterFromQuery(r *http.Request) (storage.Filter, error) {
+	q := r.URL.Query()
+	var filter storage.Filter
+
+	filter.Category = q.Get("category")
+
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return storage.Filter{}, err
+		}
+		filter.From = t
+	}
+
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return storage.Filter{}, err
+		}
+		// Filter.To is an exclusive upper bound, so push it to the start of
+		// the next day - otherwise "to=2024-03-31" would exclude every
+		// expense created after midnight on the 31st.
+		filter.To = t.AddDate(0, 0, 1)
+	}
+
+	if v := q.Get("min_amount"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return storage.Filter{}, err
+		}
+		filter.MinAmount = &n
+	}
+
+	if v := q.Get("max_amount"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return storage.Filter{}, err
+		}
+		filter.MaxAmount = &n
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Gabdullin-Nursultan/Expense-Tracker-Api/storage"
+)
+
+// idFromRequest extracts and parses the {id} path variable.
+func idFromRequest(r *http.Request) (int, error) {
+	return strconv.Atoi(mux.Vars(r)["id"])
+}
+
+// AddExpense handles POST /expenses.
+func (s *Server) AddExpense(w http.ResponseWriter, r *http.Request) {
+	var req expenseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_input", "Invalid input")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	expense := storage.Expense{
+		Description: req.Description,
+		Category:    req.Category,
+		Amount:      req.Amount,
+	}
+
+	if err := s.repo.Create(r.Context(), &expense); err != nil {
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error saving expense")
+		return
+	}
+
+	s.checkBudgetAlert(w, r, expense)
+
+	writeJSON(w, http.StatusCreated, expense)
+}
+
+// checkBudgetAlert sets the x-budget-alert header and fires the user's
+// webhook when, with expense counted, this month's spend is at or over the
+// alert threshold of the budget for expense's category (or of the overall
+// budget if that category has none). It fires for every such expense, not
+// only the one that first crossed the threshold.
+func (s *Server) checkBudgetAlert(w http.ResponseWriter, r *http.Request, expense storage.Expense) {
+	statuses, err := currentMonthBudgetStatus(r.Context(), s.repo)
+	if err != nil || statuses == nil {
+		return
+	}
+
+	status, ok := statuses[expense.Category]
+	if !ok {
+		status, ok = statuses[overallBudgetKey]
+	}
+	if !ok || !status.Alert {
+		return
+	}
+
+	w.Header().Set("x-budget-alert", "true")
+
+	user, err := s.repo.GetUserByID(r.Context(), expense.UserID)
+	if err != nil || user.WebhookURL == "" {
+		return
+	}
+
+	s.webhooks.enqueue(user.WebhookURL, map[string]interface{}{
+		"user_id":  expense.UserID,
+		"category": expense.Category,
+		"status":   status,
+	})
+}
+
+// ListExpenses handles GET /expenses?category=&from=&to=&min_amount=&max_amount=.
+func (s *Server) ListExpenses(w http.ResponseWriter, r *http.Request) {
+	filter, err := filterFromQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_filter", "Invalid filter parameters")
+		return
+	}
+
+	expenses, err := s.repo.List(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error loading expenses")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, expenses)
+}
+
+// GetExpense handles GET /expenses/{id}.
+func (s *Server) GetExpense(w http.ResponseWriter, r *http.Request) {
+	id, err := idFromRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Invalid expense ID")
+		return
+	}
+
+	expense, err := s.repo.Get(r.Context(), id)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			writeError(w, http.StatusNotFound, "not_found", "Expense not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error loading expense")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, expense)
+}
+
+// Summary handles GET /expenses/summary?category=&from=&to=&min_amount=&max_amount=.
+func (s *Server) Summary(w http.ResponseWriter, r *http.Request) {
+	filter, err := filterFromQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_filter", "Invalid filter parameters")
+		return
+	}
+
+	summary, err := s.repo.Summary(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error loading expenses")
+		return
+	}
+
+	budgetStatus, err := currentMonthBudgetStatus(r.Context(), s.repo)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error loading budget status")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summaryResponse{Summary: summary, BudgetStatus: budgetStatus})
+}
+
+// summaryResponse adds budget_status to storage.Summary's JSON shape.
+type summaryResponse struct {
+	storage.Summary
+	BudgetStatus map[string]budgetStatus `json:"budget_status,omitempty"`
+}
+
+// Categories handles GET /categories.
+func (s *Server) Categories(w http.ResponseWriter, r *http.Request) {
+	categories, err := s.repo.Categories(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error loading categories")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, categories)
+}
+
+// UpdateExpense handles PUT /expenses/{id}.
+func (s *Server) UpdateExpense(w http.ResponseWriter, r *http.Request) {
+	id, err := idFromRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Invalid expense ID")
+		return
+	}
+
+	var req expenseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_input", "Invalid input")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	updated := storage.Expense{
+		ID:          id,
+		Description: req.Description,
+		Category:    req.Category,
+		Amount:      req.Amount,
+	}
+
+	if err := s.repo.Update(r.Context(), &updated); err != nil {
+		if err == storage.ErrNotFound {
+			writeError(w, http.StatusNotFound, "not_found", "Expense not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error saving expense")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// DeleteExpense handles DELETE /expenses/{id}.
+func (s *Server) DeleteExpense(w http.ResponseWriter, r *http.Request) {
+	id, err := idFromRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "Invalid expense ID")
+		return
+	}
+
+	if err := s.repo.Delete(r.Context(), id); err != nil {
+		if err == storage.ErrNotFound {
+			writeError(w, http.StatusNotFound, "not_found", "Expense not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error saving expense")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
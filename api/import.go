@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Gabdullin-Nursultan/Expense-Tracker-Api/storage"
+)
+
+// importRowError describes one CSV row that failed validation.
+type importRowError struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// importResult is the JSON body returned by ImportExpenses.
+type importResult struct {
+	Imported int              `json:"imported"`
+	Skipped  int              `json:"skipped"`
+	Errors   []importRowError `json:"errors"`
+}
+
+// ImportExpenses handles POST /expenses/import. It accepts a
+// multipart/form-data upload under the "file" field: a CSV with columns
+// description,amount,category,created_at.
+func (s *Server) ImportExpenses(w http.ResponseWriter, r *http.Request) {
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_input", "Missing \"file\" upload")
+		return
+	}
+	defer file.Close()
+
+	result := importResult{Errors: []importRowError{}}
+
+	batch, result := parseImportCSV(file, result)
+
+	if len(batch) > 0 {
+		if err := s.repo.CreateBatch(r.Context(), batch); err != nil {
+			writeError(w, http.StatusInternalServerError, "storage_error", "Error saving imported expenses")
+			return
+		}
+		result.Imported = len(batch)
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// parseImportCSV reads header + data rows from r, returning the rows that
+// validated and a result populated with skip counts and per-row errors.
+func parseImportCSV(r io.Reader, result importResult) ([]storage.Expense, importResult) {
+	cr := csv.NewReader(r)
+
+	header, err := cr.Read()
+	if err != nil || len(header) < 4 {
+		result.Errors = append(result.Errors, importRowError{Line: 1, Reason: "missing or invalid header row"})
+		return nil, result
+	}
+
+	var batch []storage.Expense
+	line := 1
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			result.Skipped++
+			result.Errors = append(result.Errors, importRowError{Line: line, Reason: err.Error()})
+			continue
+		}
+
+		expense, reason := parseImportRow(record)
+		if reason != "" {
+			result.Skipped++
+			result.Errors = append(result.Errors, importRowError{Line: line, Reason: reason})
+			continue
+		}
+
+		batch = append(batch, expense)
+	}
+
+	return batch, result
+}
+
+// parseImportRow validates a single description,amount,category,created_at row.
+func parseImportRow(record []string) (storage.Expense, string) {
+	if len(record) < 4 {
+		return storage.Expense{}, "expected 4 columns: description,amount,category,created_at"
+	}
+
+	description, amountStr, category, createdAtStr := record[0], record[1], record[2], record[3]
+
+	if description == "" {
+		return storage.Expense{}, "description is required"
+	}
+
+	amount, err := strconv.Atoi(amountStr)
+	if err != nil || amount <= 0 {
+		return storage.Expense{}, "amount must be a positive integer"
+	}
+
+	createdAt, err := time.Parse("2006-01-02", createdAtStr)
+	if err != nil {
+		return storage.Expense{}, "created_at must be in YYYY-MM-DD format"
+	}
+
+	return storage.Expense{
+		Description: description,
+		Amount:      amount,
+		Category:    category,
+		CreatedAt:   createdAt,
+	}, ""
+}
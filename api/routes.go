@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Gabdullin-Nursultan/Expense-Tracker-Api/auth"
+)
+
+// Routes builds the router for the whole API, with common middleware
+// applied to every route and auth.Middleware additionally guarding every
+// route outside /auth/*.
+func (s *Server) Routes() http.Handler {
+	r := mux.NewRouter()
+
+	r.HandleFunc("/auth/register", s.Register).Methods(http.MethodPost)
+	r.HandleFunc("/auth/login", s.Login).Methods(http.MethodPost)
+
+	expenses := r.PathPrefix("/expenses").Subrouter()
+	expenses.Use(auth.Middleware(s.jwtSecret))
+	expenses.HandleFunc("", s.ListExpenses).Methods(http.MethodGet)
+	expenses.HandleFunc("", s.AddExpense).Methods(http.MethodPost)
+	expenses.HandleFunc("/summary", s.Summary).Methods(http.MethodGet)
+	expenses.HandleFunc("/export", s.ExportExpenses).Methods(http.MethodGet)
+	expenses.HandleFunc("/import", s.ImportExpenses).Methods(http.MethodPost)
+	expenses.HandleFunc("/{id}", s.GetExpense).Methods(http.MethodGet)
+	expenses.HandleFunc("/{id}", s.UpdateExpense).Methods(http.MethodPut)
+	expenses.HandleFunc("/{id}", s.DeleteExpense).Methods(http.MethodDelete)
+
+	categories := r.PathPrefix("/categories").Subrouter()
+	categories.Use(auth.Middleware(s.jwtSecret))
+	categories.HandleFunc("", s.Categories).Methods(http.MethodGet)
+
+	budgets := r.PathPrefix("/budgets").Subrouter()
+	budgets.Use(auth.Middleware(s.jwtSecret))
+	budgets.HandleFunc("", s.ListBudgets).Methods(http.MethodGet)
+	budgets.HandleFunc("", s.CreateBudget).Methods(http.MethodPost)
+	budgets.HandleFunc("/{id}", s.GetBudget).Methods(http.MethodGet)
+	budgets.HandleFunc("/{id}", s.UpdateBudget).Methods(http.MethodPut)
+	budgets.HandleFunc("/{id}", s.DeleteBudget).Methods(http.MethodDelete)
+
+	webhook := r.PathPrefix("/webhook").Subrouter()
+	webhook.Use(auth.Middleware(s.jwtSecret))
+	webhook.HandleFunc("", s.SetWebhook).Methods(http.MethodPut)
+
+	return Chain(r, RequestID, Recover, Logging, CORS)
+}
+
+// Middleware wraps an http.Handler with additional behavior.
+type Middleware func(http.Handler) http.Handler
+
+// Chain applies middlewares to h in order, so the first middleware passed
+// is the outermost one and runs first on the way in.
+func Chain(h http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
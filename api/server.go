@@ -0,0 +1,32 @@
+// Package api holds the HTTP handlers for the expense tracker.
+package api
+
+import (
+	"time"
+
+	"github.com/Gabdullin-Nursultan/Expense-Tracker-Api/storage"
+)
+
+// webhookWorkers is the size of the bounded pool delivering budget alerts.
+const webhookWorkers = 4
+
+// Server holds the dependencies every handler needs. Building it as a
+// struct (rather than package-level functions closing over globals) lets
+// tests inject a fake storage.Repository.
+type Server struct {
+	repo      storage.Repository
+	jwtSecret []byte
+	tokenTTL  time.Duration
+	webhooks  *webhookDispatcher
+}
+
+// NewServer returns a Server backed by repo. jwtSecret signs auth tokens
+// and tokenTTL controls how long they remain valid.
+func NewServer(repo storage.Repository, jwtSecret []byte, tokenTTL time.Duration) *Server {
+	return &Server{
+		repo:      repo,
+		jwtSecret: jwtSecret,
+		tokenTTL:  tokenTTL,
+		webhooks:  newWebhookDispatcher(webhookWorkers),
+	}
+}
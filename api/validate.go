@@ -0,0 +1,39 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// expenseRequest is the JSON body accepted by AddExpense and UpdateExpense.
+type expenseRequest struct {
+	Description string `json:"description" validate:"required"`
+	Category    string `json:"category" validate:"required"`
+	Amount      int    `json:"amount" validate:"required,gt=0"`
+}
+
+// writeValidationError responds 400 with one message per invalid field.
+func writeValidationError(w http.ResponseWriter, err error) {
+	fields := make([]string, 0)
+	for _, fe := range err.(validator.ValidationErrors) {
+		fields = append(fields, fmt.Sprintf("%s: %s", fe.Field(), fieldErrorMessage(fe)))
+	}
+
+	writeError(w, http.StatusBadRequest, "validation_failed", strings.Join(fields, "; "))
+}
+
+func fieldErrorMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	default:
+		return "is invalid"
+	}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// webhookJob is one alert to deliver to a user's configured webhook URL.
+type webhookJob struct {
+	url     string
+	payload interface{}
+}
+
+// webhookDispatcher posts budget alerts to user-configured webhooks from a
+// bounded pool of workers, so a slow or unreachable webhook never blocks
+// the HTTP handler that triggered it.
+type webhookDispatcher struct {
+	jobs   chan webhookJob
+	client *http.Client
+}
+
+const webhookQueueSize = 100
+
+// newWebhookDispatcher starts workers goroutines draining the job queue.
+func newWebhookDispatcher(workers int) *webhookDispatcher {
+	d := &webhookDispatcher{
+		jobs:   make(chan webhookJob, webhookQueueSize),
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	for i := 0; i < workers; i++ {
+		go d.worker()
+	}
+
+	return d
+}
+
+func (d *webhookDispatcher) worker() {
+	for job := range d.jobs {
+		d.deliver(job)
+	}
+}
+
+// deliver POSTs payload to job.url, retrying server errors and network
+// failures with exponential backoff before giving up.
+func (d *webhookDispatcher) deliver(job webhookJob) {
+	body, err := json.Marshal(job.payload)
+	if err != nil {
+		return
+	}
+
+	const maxAttempts = 3
+	backoff := time.Second
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		req, err := http.NewRequest(http.MethodPost, job.url, bytes.NewReader(body))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := d.client.Do(req)
+			if err == nil {
+				resp.Body.Close()
+				if resp.StatusCode < http.StatusInternalServerError {
+					return
+				}
+			}
+		}
+
+		if attempt < maxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+}
+
+// enqueue schedules a webhook delivery. If the queue is full the job is
+// dropped rather than blocking the caller.
+func (d *webhookDispatcher) enqueue(url string, payload interface{}) {
+	if url == "" {
+		return
+	}
+
+	select {
+	case d.jobs <- webhookJob{url: url, payload: payload}:
+	default:
+	}
+}
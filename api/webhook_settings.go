@@ -0,0 +1,36 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Gabdullin-Nursultan/Expense-Tracker-Api/storage"
+)
+
+// webhookRequest is the JSON body accepted by SetWebhook.
+type webhookRequest struct {
+	URL string `json:"url"`
+}
+
+// SetWebhook handles PUT /webhook, configuring where budget alerts for the
+// authenticated user are POSTed.
+func (s *Server) SetWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, ok := storage.UserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "Missing authenticated user")
+		return
+	}
+
+	var req webhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_input", "Invalid input")
+		return
+	}
+
+	if err := s.repo.SetWebhookURL(r.Context(), userID, req.URL); err != nil {
+		writeError(w, http.StatusInternalServerError, "storage_error", "Error saving webhook URL")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Gabdullin-Nursultan/Expense-Tracker-Api/storage"
+)
+
+// writeUnauthorized mirrors api.writeError's JSON error shape without
+// importing the api package, which would create an import cycle
+// (api imports auth for Middleware).
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	w.Write([]byte(`{"error":"` + message + `","code":"unauthorized"}`))
+}
+
+// Middleware validates the Authorization: Bearer <token> header on every
+// request, rejecting anonymous calls, and places the authenticated user's
+// ID in the request context via storage.WithUserID.
+func Middleware(secret []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			tokenString, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || tokenString == "" {
+				writeUnauthorized(w, "Missing or malformed Authorization header")
+				return
+			}
+
+			userID, err := ParseToken(secret, tokenString)
+			if err != nil {
+				writeUnauthorized(w, "Invalid or expired token")
+				return
+			}
+
+			ctx := storage.WithUserID(r.Context(), userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,16 @@
+// Package auth handles password hashing, JWT issuance/validation, and the
+// HTTP middleware that authenticates requests.
+package auth
+
+import "golang.org/x/crypto/bcrypt"
+
+// HashPassword returns the bcrypt hash of password for storage.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// CheckPassword reports whether password matches hash.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
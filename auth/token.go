@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned by ParseToken for any token that fails
+// signature verification, is expired, or doesn't carry a user ID.
+var ErrInvalidToken = errors.New("invalid token")
+
+// claims is the JWT payload issued on login/register.
+type claims struct {
+	UserID int `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
+// GenerateToken returns an HS256 JWT for userID, valid for ttl, signed
+// with secret.
+func GenerateToken(secret []byte, userID int, ttl time.Duration) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	})
+
+	return token.SignedString(secret)
+}
+
+// ParseToken verifies tokenString against secret and returns the user ID
+// it was issued for.
+func ParseToken(secret []byte, tokenString string) (int, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(tokenString, &c, func(t *jwt.Token) (interface{}, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{"HS256"}))
+	if err != nil || c.UserID == 0 {
+		return 0, ErrInvalidToken
+	}
+
+	return c.UserID, nil
+}
@@ -0,0 +1,79 @@
+// Package config loads server configuration from a config file and/or
+// environment variables using viper, environment variables taking
+// precedence so deployments can override the file without editing it.
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Backend identifies which storage.ExpenseRepository implementation to use.
+type Backend string
+
+const (
+	BackendJSONFile Backend = "json"
+	BackendSQLite   Backend = "sqlite"
+	BackendPostgres Backend = "postgres"
+)
+
+// Config holds everything main needs to wire the server up.
+type Config struct {
+	Addr            string
+	StorageBackend  Backend
+	JSONFilePath    string
+	UsersFilePath   string
+	BudgetsFilePath string
+	SQLiteDSN       string
+	PostgresDSN     string
+	JWTSecret       string
+	JWTTTL          time.Duration
+}
+
+// Load reads config.yaml (if present) from the working directory, then
+// overlays any matching STORAGE_BACKEND / ADDR / ... environment variables.
+func Load() (*Config, error) {
+	v := viper.New()
+
+	v.SetConfigName("config")
+	v.SetConfigType("yaml")
+	v.AddConfigPath(".")
+
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	v.SetDefault("addr", ":8080")
+	v.SetDefault("storage_backend", string(BackendJSONFile))
+	v.SetDefault("json_file_path", "expense.json")
+	v.SetDefault("users_file_path", "users.json")
+	v.SetDefault("budgets_file_path", "budgets.json")
+	v.SetDefault("sqlite_dsn", "expense.db")
+	v.SetDefault("postgres_dsn", "")
+	v.SetDefault("jwt_secret", "change-me")
+	v.SetDefault("jwt_ttl", "24h")
+
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, err
+		}
+	}
+
+	ttl, err := time.ParseDuration(v.GetString("jwt_ttl"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Config{
+		Addr:            v.GetString("addr"),
+		StorageBackend:  Backend(v.GetString("storage_backend")),
+		JSONFilePath:    v.GetString("json_file_path"),
+		UsersFilePath:   v.GetString("users_file_path"),
+		BudgetsFilePath: v.GetString("budgets_file_path"),
+		SQLiteDSN:       v.GetString("sqlite_dsn"),
+		PostgresDSN:     v.GetString("postgres_dsn"),
+		JWTSecret:       v.GetString("jwt_secret"),
+		JWTTTL:          ttl,
+	}, nil
+}
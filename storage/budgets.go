@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+// Budget caps spending in a given month, optionally scoped to one category.
+// A Budget with an empty Category applies across all categories.
+type Budget struct {
+	ID             int     `json:"id"`
+	UserID         int     `json:"user_id"`
+	Category       string  `json:"category,omitempty"`
+	Month          string  `json:"month"` // "2006-01"
+	Amount         int     `json:"amount"`
+	AlertThreshold float64 `json:"alert_threshold"`
+}
+
+// ErrBudgetNotFound is returned by GetBudget, UpdateBudget and DeleteBudget
+// when no budget matches the given ID.
+var ErrBudgetNotFound = errors.New("budget not found")
+
+// BudgetRepository stores the current user's budgets.
+type BudgetRepository interface {
+	ListBudgets(ctx context.Context) ([]Budget, error)
+	GetBudget(ctx context.Context, id int) (Budget, error)
+	CreateBudget(ctx context.Context, budget *Budget) error
+	UpdateBudget(ctx context.Context, budget *Budget) error
+	DeleteBudget(ctx context.Context, id int) error
+}
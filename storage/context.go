@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+type userIDKey struct{}
+
+// ErrNoUserID is returned by requireUserID when ctx was never passed
+// through WithUserID. It should only surface if a route is wired up
+// without auth.Middleware, since every expense and budget repository
+// method requires it.
+var ErrNoUserID = errors.New("storage: no user ID in context")
+
+// WithUserID returns a copy of ctx carrying the authenticated user's ID.
+// auth.Middleware sets this after validating a request's JWT; every
+// ExpenseRepository method reads it back to scope its work to that user.
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext returns the user ID stored by WithUserID, if any.
+func UserIDFromContext(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(userIDKey{}).(int)
+	return id, ok
+}
+
+// requireUserID is like UserIDFromContext but fails loudly instead of
+// defaulting to 0 when ctx carries no user ID - a caller who skips
+// auth.Middleware should get ErrNoUserID, not silent access to an
+// "ownerless" id-0 bucket.
+func requireUserID(ctx context.Context) (int, error) {
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return 0, ErrNoUserID
+	}
+	return userID, nil
+}
@@ -0,0 +1,43 @@
+package storage
+
+// matches reports whether e satisfies every restriction set on f.
+func (f Filter) matches(e Expense) bool {
+	if f.Category != "" && e.Category != f.Category {
+		return false
+	}
+	if !f.From.IsZero() && e.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !e.CreatedAt.Before(f.To) {
+		return false
+	}
+	if f.MinAmount != nil && e.Amount < *f.MinAmount {
+		return false
+	}
+	if f.MaxAmount != nil && e.Amount > *f.MaxAmount {
+		return false
+	}
+	return true
+}
+
+// summarize builds a Summary over expenses, all of which have already
+// passed the relevant Filter.
+func summarize(expenses []Expense) Summary {
+	s := Summary{
+		ByCategory: map[string]int{},
+		ByMonth:    map[string]int{},
+	}
+
+	for _, e := range expenses {
+		s.Total += e.Amount
+		s.Count++
+		s.ByCategory[e.Category] += e.Amount
+		s.ByMonth[e.CreatedAt.Format("2006-01")] += e.Amount
+	}
+
+	if s.Count > 0 {
+		s.Average = float64(s.Total) / float64(s.Count)
+	}
+
+	return s
+}
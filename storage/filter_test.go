@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilter_MatchesIncludesWholeToDay(t *testing.T) {
+	from, _ := time.Parse("2006-01-02", "2024-03-01")
+	to, _ := time.Parse("2006-01-02", "2024-03-31")
+	filter := Filter{From: from, To: to.AddDate(0, 0, 1)}
+
+	endOfMarch31 := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+	if !filter.matches(Expense{CreatedAt: endOfMarch31}) {
+		t.Fatalf("matches(%v) = false, want true: the whole 'to' day should be included", endOfMarch31)
+	}
+
+	startOfApril1 := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	if filter.matches(Expense{CreatedAt: startOfApril1}) {
+		t.Fatalf("matches(%v) = true, want false: the day after 'to' should be excluded", startOfApril1)
+	}
+}
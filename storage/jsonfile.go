@@ -0,0 +1,310 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// jsonFileRepository is the original storage backend: it keeps every
+// expense in a single JSON file and rewrites the whole file on every
+// mutation. It is guarded by an RWMutex so concurrent requests don't race
+// on the file. It's fine for local/dev use but does not scale, which is
+// why the SQLite and Postgres backends (sqlRepository) exist alongside it.
+//
+// User accounts and budgets each live in their own JSON file behind their
+// own mutex, since they're written far less often and on an unrelated
+// schedule from expenses.
+type jsonFileRepository struct {
+	mu   sync.RWMutex
+	path string
+
+	usersMu   sync.RWMutex
+	usersPath string
+
+	budgetsMu   sync.RWMutex
+	budgetsPath string
+}
+
+// NewJSONFileRepository returns a repository backed by the JSON files at
+// path (expenses), usersPath (user accounts) and budgetsPath (budgets).
+func NewJSONFileRepository(path, usersPath, budgetsPath string) Repository {
+	return &jsonFileRepository{path: path, usersPath: usersPath, budgetsPath: budgetsPath}
+}
+
+// load reads and decodes the whole file. Caller must hold mu.
+func (r *jsonFileRepository) load() ([]Expense, error) {
+	if _, err := os.Stat(r.path); os.IsNotExist(err) {
+		return []Expense{}, nil
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var expenses []Expense
+	if err := json.Unmarshal(data, &expenses); err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+// save re-encodes and rewrites the whole file. Caller must hold mu.
+func (r *jsonFileRepository) save(expenses []Expense) error {
+	data, err := json.MarshalIndent(expenses, "", " ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, data, 0644)
+}
+
+func (r *jsonFileRepository) List(ctx context.Context, filter Filter) ([]Expense, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	expenses, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := expenses[:0:0]
+	for _, e := range expenses {
+		if e.UserID == userID && filter.matches(e) {
+			owned = append(owned, e)
+		}
+	}
+
+	return owned, nil
+}
+
+// Stream reads the same way List does - the file is a single JSON array,
+// so decoding it is unavoidably all-or-nothing - but avoids List's second
+// "owned" slice copy and lets the caller process/flush each expense as
+// soon as it's matched instead of waiting for the whole result set.
+func (r *jsonFileRepository) Stream(ctx context.Context, filter Filter, fn func(Expense) error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	expenses, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range expenses {
+		if e.UserID == userID && filter.matches(e) {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *jsonFileRepository) Get(ctx context.Context, id int) (Expense, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	expenses, err := r.load()
+	if err != nil {
+		return Expense{}, err
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return Expense{}, err
+	}
+
+	for _, expense := range expenses {
+		if expense.ID == id && expense.UserID == userID {
+			return expense, nil
+		}
+	}
+
+	return Expense{}, ErrNotFound
+}
+
+func (r *jsonFileRepository) Create(ctx context.Context, expense *Expense) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expenses, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	maxID := 0
+	for _, e := range expenses {
+		if e.ID > maxID {
+			maxID = e.ID
+		}
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expense.ID = maxID + 1
+	expense.UserID = userID
+	expense.CreatedAt = now
+	expense.UpdatedAt = now
+
+	expenses = append(expenses, *expense)
+
+	return r.save(expenses)
+}
+
+func (r *jsonFileRepository) CreateBatch(ctx context.Context, batch []Expense) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expenses, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	maxID := 0
+	for _, e := range expenses {
+		if e.ID > maxID {
+			maxID = e.ID
+		}
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	for i := range batch {
+		maxID++
+		batch[i].ID = maxID
+		batch[i].UserID = userID
+		if batch[i].CreatedAt.IsZero() {
+			batch[i].CreatedAt = now
+		}
+		batch[i].UpdatedAt = now
+		expenses = append(expenses, batch[i])
+	}
+
+	return r.save(expenses)
+}
+
+func (r *jsonFileRepository) Update(ctx context.Context, expense *Expense) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expenses, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i, e := range expenses {
+		if e.ID == expense.ID && e.UserID == userID {
+			expenses[i].Description = expense.Description
+			expenses[i].Category = expense.Category
+			expenses[i].Amount = expense.Amount
+			expenses[i].UpdatedAt = time.Now()
+			*expense = expenses[i]
+			return r.save(expenses)
+		}
+	}
+
+	return ErrNotFound
+}
+
+func (r *jsonFileRepository) Delete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expenses, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i, e := range expenses {
+		if e.ID == id && e.UserID == userID {
+			expenses = append(expenses[:i], expenses[i+1:]...)
+			return r.save(expenses)
+		}
+	}
+
+	return ErrNotFound
+}
+
+func (r *jsonFileRepository) Summary(ctx context.Context, filter Filter) (Summary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	expenses, err := r.load()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	owned := expenses[:0:0]
+	for _, e := range expenses {
+		if e.UserID == userID && filter.matches(e) {
+			owned = append(owned, e)
+		}
+	}
+
+	return summarize(owned), nil
+}
+
+func (r *jsonFileRepository) Categories(ctx context.Context) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	expenses, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var categories []string
+	for _, e := range expenses {
+		if e.UserID == userID && e.Category != "" && !seen[e.Category] {
+			seen[e.Category] = true
+			categories = append(categories, e.Category)
+		}
+	}
+
+	return categories, nil
+}
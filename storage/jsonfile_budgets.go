@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+)
+
+func (r *jsonFileRepository) loadBudgets() ([]Budget, error) {
+	if _, err := os.Stat(r.budgetsPath); os.IsNotExist(err) {
+		return []Budget{}, nil
+	}
+
+	data, err := os.ReadFile(r.budgetsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var budgets []Budget
+	if err := json.Unmarshal(data, &budgets); err != nil {
+		return nil, err
+	}
+
+	return budgets, nil
+}
+
+func (r *jsonFileRepository) saveBudgets(budgets []Budget) error {
+	data, err := json.MarshalIndent(budgets, "", " ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.budgetsPath, data, 0644)
+}
+
+func (r *jsonFileRepository) ListBudgets(ctx context.Context) ([]Budget, error) {
+	r.budgetsMu.RLock()
+	defer r.budgetsMu.RUnlock()
+
+	budgets, err := r.loadBudgets()
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := budgets[:0:0]
+	for _, b := range budgets {
+		if b.UserID == userID {
+			owned = append(owned, b)
+		}
+	}
+
+	return owned, nil
+}
+
+func (r *jsonFileRepository) GetBudget(ctx context.Context, id int) (Budget, error) {
+	r.budgetsMu.RLock()
+	defer r.budgetsMu.RUnlock()
+
+	budgets, err := r.loadBudgets()
+	if err != nil {
+		return Budget{}, err
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return Budget{}, err
+	}
+
+	for _, b := range budgets {
+		if b.ID == id && b.UserID == userID {
+			return b, nil
+		}
+	}
+
+	return Budget{}, ErrBudgetNotFound
+}
+
+func (r *jsonFileRepository) CreateBudget(ctx context.Context, budget *Budget) error {
+	r.budgetsMu.Lock()
+	defer r.budgetsMu.Unlock()
+
+	budgets, err := r.loadBudgets()
+	if err != nil {
+		return err
+	}
+
+	maxID := 0
+	for _, b := range budgets {
+		if b.ID > maxID {
+			maxID = b.ID
+		}
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+	budget.ID = maxID + 1
+	budget.UserID = userID
+
+	budgets = append(budgets, *budget)
+
+	return r.saveBudgets(budgets)
+}
+
+func (r *jsonFileRepository) UpdateBudget(ctx context.Context, budget *Budget) error {
+	r.budgetsMu.Lock()
+	defer r.budgetsMu.Unlock()
+
+	budgets, err := r.loadBudgets()
+	if err != nil {
+		return err
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i, b := range budgets {
+		if b.ID == budget.ID && b.UserID == userID {
+			budgets[i].Category = budget.Category
+			budgets[i].Month = budget.Month
+			budgets[i].Amount = budget.Amount
+			budgets[i].AlertThreshold = budget.AlertThreshold
+			*budget = budgets[i]
+			return r.saveBudgets(budgets)
+		}
+	}
+
+	return ErrBudgetNotFound
+}
+
+func (r *jsonFileRepository) DeleteBudget(ctx context.Context, id int) error {
+	r.budgetsMu.Lock()
+	defer r.budgetsMu.Unlock()
+
+	budgets, err := r.loadBudgets()
+	if err != nil {
+		return err
+	}
+
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i, b := range budgets {
+		if b.ID == id && b.UserID == userID {
+			budgets = append(budgets[:i], budgets[i+1:]...)
+			return r.saveBudgets(budgets)
+		}
+	}
+
+	return ErrBudgetNotFound
+}
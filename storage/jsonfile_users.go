@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+func (r *jsonFileRepository) loadUsers() ([]User, error) {
+	if _, err := os.Stat(r.usersPath); os.IsNotExist(err) {
+		return []User{}, nil
+	}
+
+	data, err := os.ReadFile(r.usersPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []User
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *jsonFileRepository) saveUsers(users []User) error {
+	data, err := json.MarshalIndent(users, "", " ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.usersPath, data, 0644)
+}
+
+func (r *jsonFileRepository) CreateUser(ctx context.Context, user *User) error {
+	r.usersMu.Lock()
+	defer r.usersMu.Unlock()
+
+	users, err := r.loadUsers()
+	if err != nil {
+		return err
+	}
+
+	maxID := 0
+	for _, u := range users {
+		if u.Email == user.Email {
+			return ErrUserExists
+		}
+		if u.ID > maxID {
+			maxID = u.ID
+		}
+	}
+
+	user.ID = maxID + 1
+	user.CreatedAt = time.Now()
+
+	users = append(users, *user)
+
+	return r.saveUsers(users)
+}
+
+func (r *jsonFileRepository) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	r.usersMu.RLock()
+	defer r.usersMu.RUnlock()
+
+	users, err := r.loadUsers()
+	if err != nil {
+		return User{}, err
+	}
+
+	for _, u := range users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+
+	return User{}, ErrUserNotFound
+}
+
+func (r *jsonFileRepository) GetUserByID(ctx context.Context, id int) (User, error) {
+	r.usersMu.RLock()
+	defer r.usersMu.RUnlock()
+
+	users, err := r.loadUsers()
+	if err != nil {
+		return User{}, err
+	}
+
+	for _, u := range users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+
+	return User{}, ErrUserNotFound
+}
+
+func (r *jsonFileRepository) SetWebhookURL(ctx context.Context, userID int, url string) error {
+	r.usersMu.Lock()
+	defer r.usersMu.Unlock()
+
+	users, err := r.loadUsers()
+	if err != nil {
+		return err
+	}
+
+	for i, u := range users {
+		if u.ID == userID {
+			users[i].WebhookURL = url
+			return r.saveUsers(users)
+		}
+	}
+
+	return ErrUserNotFound
+}
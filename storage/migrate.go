@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"sort"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// runMigrations applies every embedded migration under migrations/, in
+// filename order, against db, recording each one's name in a
+// schema_migrations table so it's skipped on future calls. This is what
+// lets NewSQLiteRepository/NewPostgresRepository call it on every startup
+// instead of requiring a separate migrate step - three of the migrations
+// (ALTER TABLE ADD COLUMN) aren't safe to run twice on their own.
+func runMigrations(db *sql.DB, placehold func(n int) string) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (name TEXT PRIMARY KEY)`); err != nil {
+		return err
+	}
+
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var applied int
+		query := fmt.Sprintf(`SELECT COUNT(*) FROM schema_migrations WHERE name = %s`, placehold(1))
+		if err := db.QueryRow(query, name).Scan(&applied); err != nil {
+			return err
+		}
+		if applied > 0 {
+			continue
+		}
+
+		data, err := migrationFiles.ReadFile("migrations/" + name)
+		if err != nil {
+			return err
+		}
+		if _, err := db.Exec(string(data)); err != nil {
+			return fmt.Errorf("applying migration %s: %w", name, err)
+		}
+
+		insert := fmt.Sprintf(`INSERT INTO schema_migrations (name) VALUES (%s)`, placehold(1))
+		if _, err := db.Exec(insert, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Expense is a single tracked expense, owned by exactly one user.
+type Expense struct {
+	ID          int       `json:"id"`
+	UserID      int       `json:"user_id"`
+	Description string    `json:"description"`
+	Category    string    `json:"category"`
+	Amount      int       `json:"amount"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ErrNotFound is returned by Get, Update and Delete when no expense matches the given ID.
+var ErrNotFound = errors.New("expense not found")
+
+// Filter narrows down the results returned by List and Summary.
+// Zero values mean "no restriction" on that field. To is an exclusive
+// upper bound (e.g. the start of the day after the last day to include),
+// not the instant to stop at.
+type Filter struct {
+	Category  string
+	From      time.Time
+	To        time.Time
+	MinAmount *int
+	MaxAmount *int
+}
+
+// Summary aggregates a set of expenses.
+type Summary struct {
+	Total      int            `json:"total"`
+	Count      int            `json:"count"`
+	ByCategory map[string]int `json:"by_category"`
+	ByMonth    map[string]int `json:"by_month"`
+	Average    float64        `json:"average"`
+}
+
+// ExpenseRepository is implemented by every storage backend (JSON file,
+// SQLite, Postgres, ...). Handlers depend on this interface rather than a
+// concrete backend so tests can inject a fake implementation.
+//
+// Every method scopes its work to the user found in ctx (see WithUserID) -
+// a caller can never list, read, or mutate another user's expenses.
+type ExpenseRepository interface {
+	List(ctx context.Context, filter Filter) ([]Expense, error)
+	// Stream calls fn once for every expense matching filter and owned by
+	// ctx's user, in ID order, without building the result slice the way
+	// List does (the SQL backends also read one row at a time; the
+	// JSON-file backend decodes its whole file first). It stops and
+	// returns fn's error as soon as fn returns one. Used by the CSV/JSON
+	// exporter.
+	Stream(ctx context.Context, filter Filter, fn func(Expense) error) error
+	Get(ctx context.Context, id int) (Expense, error)
+	Create(ctx context.Context, expense *Expense) error
+	// CreateBatch inserts expenses in a single transaction, assigning each
+	// its ID and timestamps. Used by the CSV importer for the rows that
+	// passed validation: if any insert fails, none of the batch is kept,
+	// rather than leaving a partial import behind.
+	CreateBatch(ctx context.Context, expenses []Expense) error
+	Update(ctx context.Context, expense *Expense) error
+	Delete(ctx context.Context, id int) error
+	Summary(ctx context.Context, filter Filter) (Summary, error)
+	Categories(ctx context.Context) ([]string, error)
+}
+
+// Repository is the full storage contract: expenses, the user accounts
+// that own them, and their budgets. Each backend (JSON file, SQLite,
+// Postgres) implements every half so main only has to construct one value
+// per backend.
+type Repository interface {
+	ExpenseRepository
+	UserRepository
+	BudgetRepository
+}
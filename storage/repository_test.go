@@ -0,0 +1,285 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// applyMigrations runs the same migrations NewSQLiteRepository applies in
+// production, against db, so tests exercise the real migration path
+// instead of a separate hand-rolled one.
+func applyMigrations(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	if err := runMigrations(db, questionPlaceholder); err != nil {
+		t.Fatalf("applying migrations: %v", err)
+	}
+}
+
+// newSQLiteTestRepository returns a Repository backed by a fresh on-disk
+// SQLite database with every migration applied, cleaned up when the test
+// finishes.
+func newSQLiteTestRepository(t *testing.T) Repository {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "expenses.db")
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		t.Fatalf("opening sqlite db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	applyMigrations(t, db)
+
+	return &sqlRepository{db: db, placehold: questionPlaceholder}
+}
+
+// newJSONFileTestRepository returns a Repository backed by JSON files in a
+// fresh temp directory.
+func newJSONFileTestRepository(t *testing.T) Repository {
+	t.Helper()
+
+	dir := t.TempDir()
+	return NewJSONFileRepository(
+		filepath.Join(dir, "expenses.json"),
+		filepath.Join(dir, "users.json"),
+		filepath.Join(dir, "budgets.json"),
+	)
+}
+
+// testBackends lists every backend exercised by the table-driven tests
+// below. Postgres is excluded: it needs a live server, which CI here
+// doesn't provide, and has no pure-Go embedded substitute like SQLite.
+func testBackends(t *testing.T) map[string]Repository {
+	return map[string]Repository{
+		"jsonfile": newJSONFileTestRepository(t),
+		"sqlite":   newSQLiteTestRepository(t),
+	}
+}
+
+func TestExpenseRepository_CRUD(t *testing.T) {
+	for name, repo := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := WithUserID(context.Background(), 1)
+
+			created := Expense{Description: "coffee", Category: "food", Amount: 350}
+			if err := repo.Create(ctx, &created); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			if created.ID == 0 {
+				t.Fatalf("Create did not assign an ID")
+			}
+
+			got, err := repo.Get(ctx, created.ID)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if got.Description != "coffee" || got.Amount != 350 {
+				t.Fatalf("Get returned %+v, want description=coffee amount=350", got)
+			}
+
+			got.Description = "espresso"
+			got.Amount = 400
+			if err := repo.Update(ctx, &got); err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+
+			updated, err := repo.Get(ctx, created.ID)
+			if err != nil {
+				t.Fatalf("Get after Update: %v", err)
+			}
+			if updated.Description != "espresso" || updated.Amount != 400 {
+				t.Fatalf("Get after Update returned %+v", updated)
+			}
+
+			list, err := repo.List(ctx, Filter{})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(list) != 1 {
+				t.Fatalf("List returned %d expenses, want 1", len(list))
+			}
+
+			if err := repo.Delete(ctx, created.ID); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+
+			if _, err := repo.Get(ctx, created.ID); err != ErrNotFound {
+				t.Fatalf("Get after Delete returned err=%v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestExpenseRepository_Stream(t *testing.T) {
+	for name, repo := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := WithUserID(context.Background(), 1)
+
+			for _, amount := range []int{100, 200, 300} {
+				e := Expense{Description: "x", Amount: amount}
+				if err := repo.Create(ctx, &e); err != nil {
+					t.Fatalf("Create: %v", err)
+				}
+			}
+
+			var streamed []Expense
+			err := repo.Stream(ctx, Filter{}, func(e Expense) error {
+				streamed = append(streamed, e)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Stream: %v", err)
+			}
+			if len(streamed) != 3 {
+				t.Fatalf("Stream visited %d expenses, want 3", len(streamed))
+			}
+
+			stopErr := errors.New("stop")
+			count := 0
+			err = repo.Stream(ctx, Filter{}, func(Expense) error {
+				count++
+				return stopErr
+			})
+			if err != stopErr {
+				t.Fatalf("Stream returned err=%v, want the callback's error", err)
+			}
+			if count != 1 {
+				t.Fatalf("Stream called fn %d times after an error, want 1", count)
+			}
+		})
+	}
+}
+
+func TestExpenseRepository_ScopedToUser(t *testing.T) {
+	for name, repo := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			alice := WithUserID(context.Background(), 1)
+			bob := WithUserID(context.Background(), 2)
+
+			aliceExpense := Expense{Description: "alice's lunch", Amount: 100}
+			if err := repo.Create(alice, &aliceExpense); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+
+			if _, err := repo.Get(bob, aliceExpense.ID); err != ErrNotFound {
+				t.Fatalf("bob's Get returned err=%v, want ErrNotFound", err)
+			}
+
+			bobList, err := repo.List(bob, Filter{})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(bobList) != 0 {
+				t.Fatalf("bob's List returned %d expenses, want 0", len(bobList))
+			}
+		})
+	}
+}
+
+func TestExpenseRepository_RequiresUserID(t *testing.T) {
+	for name, repo := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if _, err := repo.List(ctx, Filter{}); err != ErrNoUserID {
+				t.Fatalf("List without a user ID returned err=%v, want ErrNoUserID", err)
+			}
+			if err := repo.Create(ctx, &Expense{Description: "x", Amount: 1}); err != ErrNoUserID {
+				t.Fatalf("Create without a user ID returned err=%v, want ErrNoUserID", err)
+			}
+		})
+	}
+}
+
+func TestUserRepository_CRUD(t *testing.T) {
+	for name, repo := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			user := User{Email: "alice@example.com", PasswordHash: "hashed-password"}
+			if err := repo.CreateUser(ctx, &user); err != nil {
+				t.Fatalf("CreateUser: %v", err)
+			}
+			if user.ID == 0 {
+				t.Fatalf("CreateUser did not assign an ID")
+			}
+
+			byEmail, err := repo.GetUserByEmail(ctx, "alice@example.com")
+			if err != nil {
+				t.Fatalf("GetUserByEmail: %v", err)
+			}
+			if byEmail.PasswordHash != "hashed-password" {
+				t.Fatalf("GetUserByEmail returned PasswordHash=%q, want %q", byEmail.PasswordHash, "hashed-password")
+			}
+
+			byID, err := repo.GetUserByID(ctx, user.ID)
+			if err != nil {
+				t.Fatalf("GetUserByID: %v", err)
+			}
+			if byID.Email != "alice@example.com" || byID.PasswordHash != "hashed-password" {
+				t.Fatalf("GetUserByID returned %+v", byID)
+			}
+
+			if err := repo.CreateUser(ctx, &User{Email: "alice@example.com", PasswordHash: "other"}); err != ErrUserExists {
+				t.Fatalf("CreateUser with a duplicate email returned err=%v, want ErrUserExists", err)
+			}
+
+			if _, err := repo.GetUserByEmail(ctx, "nobody@example.com"); err != ErrUserNotFound {
+				t.Fatalf("GetUserByEmail for an unknown email returned err=%v, want ErrUserNotFound", err)
+			}
+			if _, err := repo.GetUserByID(ctx, user.ID+1); err != ErrUserNotFound {
+				t.Fatalf("GetUserByID for an unknown ID returned err=%v, want ErrUserNotFound", err)
+			}
+
+			if err := repo.SetWebhookURL(ctx, user.ID, "https://example.com/hook"); err != nil {
+				t.Fatalf("SetWebhookURL: %v", err)
+			}
+			updated, err := repo.GetUserByID(ctx, user.ID)
+			if err != nil {
+				t.Fatalf("GetUserByID after SetWebhookURL: %v", err)
+			}
+			if updated.WebhookURL != "https://example.com/hook" {
+				t.Fatalf("GetUserByID after SetWebhookURL returned WebhookURL=%q", updated.WebhookURL)
+			}
+		})
+	}
+}
+
+func TestBudgetRepository_CRUD(t *testing.T) {
+	for name, repo := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := WithUserID(context.Background(), 1)
+
+			budget := Budget{Category: "food", Month: "2024-03", Amount: 10000, AlertThreshold: 0.8}
+			if err := repo.CreateBudget(ctx, &budget); err != nil {
+				t.Fatalf("CreateBudget: %v", err)
+			}
+
+			got, err := repo.GetBudget(ctx, budget.ID)
+			if err != nil {
+				t.Fatalf("GetBudget: %v", err)
+			}
+			if got.Amount != 10000 {
+				t.Fatalf("GetBudget returned %+v, want amount=10000", got)
+			}
+
+			got.Amount = 20000
+			if err := repo.UpdateBudget(ctx, &got); err != nil {
+				t.Fatalf("UpdateBudget: %v", err)
+			}
+
+			if err := repo.DeleteBudget(ctx, budget.ID); err != nil {
+				t.Fatalf("DeleteBudget: %v", err)
+			}
+
+			if _, err := repo.GetBudget(ctx, budget.ID); err != ErrBudgetNotFound {
+				t.Fatalf("GetBudget after Delete returned err=%v, want ErrBudgetNotFound", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,471 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// sqlRepository implements Repository on top of database/sql. It's shared
+// by NewSQLiteRepository and NewPostgresRepository, which only differ in
+// driver name, DSN and placeholder syntax.
+type sqlRepository struct {
+	db        *sql.DB
+	placehold func(n int) string // e.g. "?" for sqlite, "$1" for postgres
+}
+
+func questionPlaceholder(n int) string { return "?" }
+
+func dollarPlaceholder(n int) string { return fmt.Sprintf("$%d", n) }
+
+// whereClause builds the "WHERE ..." fragment (and its positional args)
+// for filter, scoped to userID. args[0] is always userID.
+func (r *sqlRepository) whereClause(userID int, filter Filter) (string, []interface{}) {
+	clause := fmt.Sprintf("user_id = %s", r.placehold(1))
+	args := []interface{}{userID}
+
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		clause += fmt.Sprintf(" AND category = %s", r.placehold(len(args)))
+	}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		clause += fmt.Sprintf(" AND created_at >= %s", r.placehold(len(args)))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		clause += fmt.Sprintf(" AND created_at < %s", r.placehold(len(args)))
+	}
+	if filter.MinAmount != nil {
+		args = append(args, *filter.MinAmount)
+		clause += fmt.Sprintf(" AND amount >= %s", r.placehold(len(args)))
+	}
+	if filter.MaxAmount != nil {
+		args = append(args, *filter.MaxAmount)
+		clause += fmt.Sprintf(" AND amount <= %s", r.placehold(len(args)))
+	}
+
+	return clause, args
+}
+
+func (r *sqlRepository) List(ctx context.Context, filter Filter) ([]Expense, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where, args := r.whereClause(userID, filter)
+
+	query := fmt.Sprintf(`SELECT id, user_id, description, category, amount, created_at, updated_at FROM expenses WHERE %s ORDER BY id`, where)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expenses := []Expense{}
+	for rows.Next() {
+		var e Expense
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Description, &e.Category, &e.Amount, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, e)
+	}
+
+	return expenses, rows.Err()
+}
+
+// Stream scans rows one at a time straight off the driver cursor instead
+// of buffering them into a slice like List does, so a large export never
+// holds more than one row in memory at once.
+func (r *sqlRepository) Stream(ctx context.Context, filter Filter, fn func(Expense) error) error {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+	where, args := r.whereClause(userID, filter)
+
+	query := fmt.Sprintf(`SELECT id, user_id, description, category, amount, created_at, updated_at FROM expenses WHERE %s ORDER BY id`, where)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e Expense
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Description, &e.Category, &e.Amount, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *sqlRepository) Get(ctx context.Context, id int) (Expense, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return Expense{}, err
+	}
+
+	query := fmt.Sprintf(
+		`SELECT id, user_id, description, category, amount, created_at, updated_at FROM expenses WHERE id = %s AND user_id = %s`,
+		r.placehold(1), r.placehold(2),
+	)
+
+	var e Expense
+	err = r.db.QueryRowContext(ctx, query, id, userID).Scan(&e.ID, &e.UserID, &e.Description, &e.Category, &e.Amount, &e.CreatedAt, &e.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return Expense{}, ErrNotFound
+	}
+	if err != nil {
+		return Expense{}, err
+	}
+
+	return e, nil
+}
+
+func (r *sqlRepository) Create(ctx context.Context, expense *Expense) error {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+	expense.UserID = userID
+
+	// RETURNING id works on both drivers (Postgres always supported it,
+	// SQLite added it in 3.35+), which lets both backends share this
+	// query instead of one using LastInsertId and the other RETURNING.
+	query := fmt.Sprintf(
+		`INSERT INTO expenses (user_id, description, category, amount, created_at, updated_at) VALUES (%s, %s, %s, %s, %s, %s) RETURNING id`,
+		r.placehold(1), r.placehold(2), r.placehold(3), r.placehold(4), r.placehold(5), r.placehold(6),
+	)
+
+	return r.db.QueryRowContext(ctx, query, expense.UserID, expense.Description, expense.Category, expense.Amount, expense.CreatedAt, expense.UpdatedAt).Scan(&expense.ID)
+}
+
+func (r *sqlRepository) CreateBatch(ctx context.Context, batch []Expense) error {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(
+		`INSERT INTO expenses (user_id, description, category, amount, created_at, updated_at) VALUES (%s, %s, %s, %s, %s, %s) RETURNING id`,
+		r.placehold(1), r.placehold(2), r.placehold(3), r.placehold(4), r.placehold(5), r.placehold(6),
+	)
+
+	now := time.Now()
+	for i := range batch {
+		batch[i].UserID = userID
+		if batch[i].CreatedAt.IsZero() {
+			batch[i].CreatedAt = now
+		}
+		batch[i].UpdatedAt = now
+		if err := tx.QueryRowContext(ctx, query, batch[i].UserID, batch[i].Description, batch[i].Category, batch[i].Amount, batch[i].CreatedAt, batch[i].UpdatedAt).Scan(&batch[i].ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *sqlRepository) Update(ctx context.Context, expense *Expense) error {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(
+		`UPDATE expenses SET description = %s, category = %s, amount = %s, updated_at = %s WHERE id = %s AND user_id = %s`,
+		r.placehold(1), r.placehold(2), r.placehold(3), r.placehold(4), r.placehold(5), r.placehold(6),
+	)
+
+	res, err := r.db.ExecContext(ctx, query, expense.Description, expense.Category, expense.Amount, expense.UpdatedAt, expense.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *sqlRepository) Delete(ctx context.Context, id int) error {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`DELETE FROM expenses WHERE id = %s AND user_id = %s`, r.placehold(1), r.placehold(2))
+
+	res, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *sqlRepository) Summary(ctx context.Context, filter Filter) (Summary, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return Summary{}, err
+	}
+	where, args := r.whereClause(userID, filter)
+
+	// The aggregation logic (group by category/month, compute the average)
+	// is identical to the in-memory path, so we just reuse summarize over
+	// the already-filtered rows instead of writing separate SQL for it.
+	query := fmt.Sprintf(`SELECT category, amount, created_at FROM expenses WHERE %s`, where)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return Summary{}, err
+	}
+	defer rows.Close()
+
+	var expenses []Expense
+	for rows.Next() {
+		var e Expense
+		if err := rows.Scan(&e.Category, &e.Amount, &e.CreatedAt); err != nil {
+			return Summary{}, err
+		}
+		expenses = append(expenses, e)
+	}
+	if err := rows.Err(); err != nil {
+		return Summary{}, err
+	}
+
+	return summarize(expenses), nil
+}
+
+func (r *sqlRepository) Categories(ctx context.Context) ([]string, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(
+		`SELECT DISTINCT category FROM expenses WHERE user_id = %s AND category != ''`,
+		r.placehold(1),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+
+	return categories, rows.Err()
+}
+
+// isUniqueViolation reports whether err comes from a unique index conflict.
+// sqlite and pq don't share an error type, so this matches on message text
+// rather than importing both drivers' error packages here.
+func isUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint") || strings.Contains(msg, "duplicate key")
+}
+
+func (r *sqlRepository) CreateUser(ctx context.Context, user *User) error {
+	query := fmt.Sprintf(
+		`INSERT INTO users (email, password_hash, created_at) VALUES (%s, %s, %s) RETURNING id`,
+		r.placehold(1), r.placehold(2), r.placehold(3),
+	)
+
+	err := r.db.QueryRowContext(ctx, query, user.Email, user.PasswordHash, user.CreatedAt).Scan(&user.ID)
+	if isUniqueViolation(err) {
+		return ErrUserExists
+	}
+	return err
+}
+
+func (r *sqlRepository) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	query := fmt.Sprintf(`SELECT id, email, password_hash, webhook_url, created_at FROM users WHERE email = %s`, r.placehold(1))
+
+	var u User
+	err := r.db.QueryRowContext(ctx, query, email).Scan(&u.ID, &u.Email, &u.PasswordHash, &u.WebhookURL, &u.CreatedAt)
+	if err == sql.ErrNoRows {
+		return User{}, ErrUserNotFound
+	}
+	return u, err
+}
+
+func (r *sqlRepository) GetUserByID(ctx context.Context, id int) (User, error) {
+	query := fmt.Sprintf(`SELECT id, email, password_hash, webhook_url, created_at FROM users WHERE id = %s`, r.placehold(1))
+
+	var u User
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&u.ID, &u.Email, &u.PasswordHash, &u.WebhookURL, &u.CreatedAt)
+	if err == sql.ErrNoRows {
+		return User{}, ErrUserNotFound
+	}
+	return u, err
+}
+
+func (r *sqlRepository) SetWebhookURL(ctx context.Context, userID int, url string) error {
+	query := fmt.Sprintf(`UPDATE users SET webhook_url = %s WHERE id = %s`, r.placehold(1), r.placehold(2))
+
+	res, err := r.db.ExecContext(ctx, query, url, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *sqlRepository) ListBudgets(ctx context.Context) ([]Budget, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT id, user_id, category, month, amount, alert_threshold FROM budgets WHERE user_id = %s ORDER BY id`, r.placehold(1))
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	budgets := []Budget{}
+	for rows.Next() {
+		var b Budget
+		if err := rows.Scan(&b.ID, &b.UserID, &b.Category, &b.Month, &b.Amount, &b.AlertThreshold); err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, b)
+	}
+
+	return budgets, rows.Err()
+}
+
+func (r *sqlRepository) GetBudget(ctx context.Context, id int) (Budget, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return Budget{}, err
+	}
+
+	query := fmt.Sprintf(
+		`SELECT id, user_id, category, month, amount, alert_threshold FROM budgets WHERE id = %s AND user_id = %s`,
+		r.placehold(1), r.placehold(2),
+	)
+
+	var b Budget
+	err = r.db.QueryRowContext(ctx, query, id, userID).Scan(&b.ID, &b.UserID, &b.Category, &b.Month, &b.Amount, &b.AlertThreshold)
+	if err == sql.ErrNoRows {
+		return Budget{}, ErrBudgetNotFound
+	}
+	return b, err
+}
+
+func (r *sqlRepository) CreateBudget(ctx context.Context, budget *Budget) error {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+	budget.UserID = userID
+
+	query := fmt.Sprintf(
+		`INSERT INTO budgets (user_id, category, month, amount, alert_threshold) VALUES (%s, %s, %s, %s, %s) RETURNING id`,
+		r.placehold(1), r.placehold(2), r.placehold(3), r.placehold(4), r.placehold(5),
+	)
+
+	return r.db.QueryRowContext(ctx, query, budget.UserID, budget.Category, budget.Month, budget.Amount, budget.AlertThreshold).Scan(&budget.ID)
+}
+
+func (r *sqlRepository) UpdateBudget(ctx context.Context, budget *Budget) error {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(
+		`UPDATE budgets SET category = %s, month = %s, amount = %s, alert_threshold = %s WHERE id = %s AND user_id = %s`,
+		r.placehold(1), r.placehold(2), r.placehold(3), r.placehold(4), r.placehold(5), r.placehold(6),
+	)
+
+	res, err := r.db.ExecContext(ctx, query, budget.Category, budget.Month, budget.Amount, budget.AlertThreshold, budget.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrBudgetNotFound
+	}
+
+	return nil
+}
+
+func (r *sqlRepository) DeleteBudget(ctx context.Context, id int) error {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`DELETE FROM budgets WHERE id = %s AND user_id = %s`, r.placehold(1), r.placehold(2))
+
+	res, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrBudgetNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"database/sql"
+
+	_ "modernc.org/sqlite"
+)
+
+// NewSQLiteRepository opens (creating if necessary) a SQLite database at
+// dsn, applies any pending migrations under storage/migrations, and
+// returns a repository backed by it.
+func NewSQLiteRepository(dsn string) (Repository, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite only allows one writer at a time; a single connection avoids
+	// "database is locked" errors under concurrent requests.
+	db.SetMaxOpenConns(1)
+
+	if err := runMigrations(db, questionPlaceholder); err != nil {
+		return nil, err
+	}
+
+	return &sqlRepository{db: db, placehold: questionPlaceholder}, nil
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// User is an account that owns a set of expenses.
+type User struct {
+	ID           int       `json:"id"`
+	Email        string    `json:"email"`
+	PasswordHash string    `json:"password_hash"`
+	WebhookURL   string    `json:"webhook_url,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ErrUserNotFound is returned when no user matches the given email or ID.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrUserExists is returned by CreateUser when the email is already registered.
+var ErrUserExists = errors.New("user already exists")
+
+// UserRepository stores user accounts.
+type UserRepository interface {
+	CreateUser(ctx context.Context, user *User) error
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserByID(ctx context.Context, id int) (User, error)
+	// SetWebhookURL updates the webhook the budget alert dispatcher posts to.
+	SetWebhookURL(ctx context.Context, userID int, url string) error
+}